@@ -6,12 +6,15 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
 	"github.com/ethereum-optimism/optimism/op-supervisor/config"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/backoff"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/da_syncer"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/source"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/frontend"
@@ -26,7 +29,15 @@ type SupervisorBackend struct {
 	logger  log.Logger
 
 	chainMonitors []*source.ChainMonitor
+	supervisors   []*chainSupervisor
+	daSyncer      *da_syncer.DASyncer
 	logDBs        []*db.DB
+
+	heads    *headTracker
+	resolver *SafetyResolver
+
+	supervisorCancel context.CancelFunc
+	supervisorWG     sync.WaitGroup
 }
 
 var _ frontend.Backend = (*SupervisorBackend)(nil)
@@ -34,12 +45,40 @@ var _ frontend.Backend = (*SupervisorBackend)(nil)
 var _ io.Closer = (*SupervisorBackend)(nil)
 
 func NewSupervisorBackend(ctx context.Context, logger log.Logger, m Metrics, cfg *config.Config) (*SupervisorBackend, error) {
-	chainMonitors := make([]*source.ChainMonitor, len(cfg.L2RPCs))
+	runRPC := cfg.SyncMode == config.SyncModeRPC || cfg.SyncMode == config.SyncModeHybrid
+	runDA := cfg.SyncMode == config.SyncModeDA || cfg.SyncMode == config.SyncModeHybrid
+	if runDA {
+		// TODO(protocol-quest#288): da_syncer can't yet decode real batcher
+		// calldata/blobs (see da_syncer/decode.go), so DA and Hybrid sync modes
+		// would just spin retrying the same L1 block forever if allowed to
+		// start. Refuse up front rather than expose a sync mode that looks
+		// selectable but never makes progress; drop this once decoding lands.
+		return nil, errors.New("DA and Hybrid sync modes are not yet supported: da_syncer batch decoding is unimplemented (protocol-quest#288)")
+	}
+
+	var chainMonitors []*source.ChainMonitor
+	var supervisors []*chainSupervisor
+	if runRPC {
+		chainMonitors = make([]*source.ChainMonitor, len(cfg.L2RPCs))
+		supervisors = make([]*chainSupervisor, len(cfg.L2RPCs))
+	}
 	logDBs := make([]*db.DB, len(cfg.L2RPCs))
-	for i, rpc := range cfg.L2RPCs {
-		rpcClient, chainID, err := createRpcClient(ctx, logger, rpc)
-		if err != nil {
-			return nil, err
+	heads := newHeadTracker()
+	resolver := NewSafetyResolver(heads)
+	for i, rpcEndpoints := range cfg.L2RPCs {
+		var rpcClient client.RPC
+		var chainID types.ChainID
+		var err error
+		if runRPC {
+			rpcClient, chainID, err = createRpcClient(ctx, logger, m, cfg, rpcEndpoints)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			chainID, err = probeChainID(ctx, logger, rpcEndpoints)
+			if err != nil {
+				return nil, err
+			}
 		}
 		cm := newChainMetrics(chainID, m)
 		path, err := prepLogDBPath(chainID, cfg.Datadir)
@@ -51,6 +90,7 @@ func NewSupervisorBackend(ctx context.Context, logger log.Logger, m Metrics, cfg
 			return nil, fmt.Errorf("failed to create logdb for chain %v at %v: %w", chainID, path, err)
 		}
 		logDBs[i] = logDB
+		resolver.AddChain(chainID, newDBLogDB(logDB))
 
 		// Get the last checkpoint that was written then Rewind the db
 		// to the block prior to that block and start from there.
@@ -65,47 +105,190 @@ func NewSupervisorBackend(ctx context.Context, logger log.Logger, m Metrics, cfg
 		if err != nil {
 			return nil, fmt.Errorf("failed to 'Rewind' the database: %w", err)
 		}
-		monitor, err := source.NewChainMonitor(ctx, logger, cm, chainID, rpc, rpcClient, block)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create monitor for rpc %v: %w", rpc, err)
+		if runRPC {
+			monitor, err := source.NewChainMonitor(ctx, logger, cm, chainID, rpcEndpoints[0], rpcClient, block)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create monitor for rpc %v: %w", rpcEndpoints[0], err)
+			}
+			chainMonitors[i] = monitor
+			strategy := backoff.NewExponential(defaultBackoffBase, defaultBackoffMax, defaultBackoffJitter)
+			healthMetrics, _ := m.(HealthMetrics)
+			supervisors[i] = newChainSupervisor(logger, chainID, monitor, strategy, healthMetrics, heads, logDB)
 		}
-		chainMonitors[i] = monitor
 	}
+
 	return &SupervisorBackend{
 		logger:        logger,
 		chainMonitors: chainMonitors,
+		supervisors:   supervisors,
 		logDBs:        logDBs,
+		heads:         heads,
+		resolver:      resolver,
 	}, nil
 }
 
-func createRpcClient(ctx context.Context, logger log.Logger, rpc string) (client.RPC, types.ChainID, error) {
-	ethClient, err := dial.DialEthClientWithTimeout(ctx, 10*time.Second, logger, rpc)
+// createRpcClient connects to every configured RPC endpoint for a chain and,
+// when more than one is configured, wraps them in a FallbackClient so the
+// monitor transparently rides out a single endpoint going unhealthy. All
+// endpoints must agree on the chain ID.
+func createRpcClient(ctx context.Context, logger log.Logger, m Metrics, cfg *config.Config, rpcEndpoints []string) (client.RPC, types.ChainID, error) {
+	if len(rpcEndpoints) == 0 {
+		return nil, types.ChainID{}, errors.New("no RPC endpoints configured for chain")
+	}
+	clients := make([]client.RPC, len(rpcEndpoints))
+	var chainID types.ChainID
+	for i, rpc := range rpcEndpoints {
+		ethClient, err := dial.DialEthClientWithTimeout(ctx, 10*time.Second, logger, rpc)
+		if err != nil {
+			return nil, types.ChainID{}, fmt.Errorf("failed to connect to rpc %v: %w", rpc, err)
+		}
+		id, err := ethClient.ChainID(ctx)
+		if err != nil {
+			return nil, types.ChainID{}, fmt.Errorf("failed to load chain id for rpc %v: %w", rpc, err)
+		}
+		thisChainID := types.ChainIDFromBig(id)
+		if i == 0 {
+			chainID = thisChainID
+		} else if thisChainID != chainID {
+			return nil, types.ChainID{}, fmt.Errorf("rpc %v reports chain id %v, expected %v", rpc, thisChainID, chainID)
+		}
+		clients[i] = client.NewBaseRPCClient(ethClient.Client())
+	}
+	if len(clients) == 1 {
+		return clients[0], chainID, nil
+	}
+	fallbackMetrics, _ := m.(FallbackMetrics)
+	fc, err := NewFallbackClient(logger, chainID, fallbackMetrics, rpcEndpoints, clients, cfg.FallbackThreshold)
+	if err != nil {
+		return nil, types.ChainID{}, fmt.Errorf("failed to create fallback client: %w", err)
+	}
+	return fc, chainID, nil
+}
+
+// probeChainID connects to the first configured RPC endpoint just long enough
+// to learn the chain ID, for the DA-only sync mode where no long-lived RPC
+// client is kept around.
+func probeChainID(ctx context.Context, logger log.Logger, rpcEndpoints []string) (types.ChainID, error) {
+	if len(rpcEndpoints) == 0 {
+		return types.ChainID{}, errors.New("no RPC endpoints configured for chain")
+	}
+	ethClient, err := dial.DialEthClientWithTimeout(ctx, 10*time.Second, logger, rpcEndpoints[0])
 	if err != nil {
-		return nil, types.ChainID{}, fmt.Errorf("failed to connect to rpc %v: %w", rpc, err)
+		return types.ChainID{}, fmt.Errorf("failed to connect to rpc %v: %w", rpcEndpoints[0], err)
 	}
+	defer ethClient.Close()
 	chainID, err := ethClient.ChainID(ctx)
 	if err != nil {
-		return nil, types.ChainID{}, fmt.Errorf("failed to load chain id for rpc %v: %w", rpc, err)
+		return types.ChainID{}, fmt.Errorf("failed to load chain id for rpc %v: %w", rpcEndpoints[0], err)
 	}
-	return client.NewBaseRPCClient(ethClient.Client()), types.ChainIDFromBig(chainID), nil
+	return types.ChainIDFromBig(chainID), nil
+}
+
+// createL1Source dials the L1 RPC used by the DA syncer to read batcher
+// transactions and blocks.
+//
+// TODO(protocol-quest#288): unused while DA/Hybrid sync mode is refused at
+// startup (see the runDA check above) - da_syncer's batch decoding is
+// disabled scaffolding, not a wired feature. Kept so the DA construction path
+// doesn't need to be rebuilt from scratch once decoding lands.
+func createL1Source(ctx context.Context, logger log.Logger, l1RPC string) (da_syncer.L1Source, error) {
+	ethClient, err := dial.DialEthClientWithTimeout(ctx, 10*time.Second, logger, l1RPC)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to L1 rpc %v: %w", l1RPC, err)
+	}
+	return ethClient, nil
+}
+
+// createBlobClient builds the DA syncer's blob source from cfg: a
+// BlobClientList over the beacon node and/or a blobscan-compatible API
+// (useful for blobs the beacon node has already pruned). The list
+// round-robins between them, so neither one is preferred.
+//
+// TODO(protocol-quest#288): unused for the same reason as createL1Source.
+func createBlobClient(cfg *config.Config) (da_syncer.BlobClient, error) {
+	var clients []da_syncer.BlobClient
+	if cfg.BeaconNodeURL != "" {
+		clients = append(clients, da_syncer.NewBeaconNodeClient(newBeaconNodeAPI(cfg.BeaconNodeURL)))
+	}
+	if cfg.BlobArchiverURL != "" {
+		clients = append(clients, da_syncer.NewBlobScanClient(cfg.BlobArchiverURL, httpGet))
+	}
+	if len(clients) == 0 {
+		return nil, errors.New("DA sync mode requires a beacon node or blob archiver URL")
+	}
+	return da_syncer.NewBlobClientList(clients...)
 }
 
 func (su *SupervisorBackend) Start(ctx context.Context) error {
 	if !su.started.CompareAndSwap(false, true) {
 		return errors.New("already started")
 	}
-	for _, monitor := range su.chainMonitors {
-		if err := monitor.Start(); err != nil {
-			return fmt.Errorf("failed to start chain monitor: %w", err)
-		}
+	supervisorCtx, cancel := context.WithCancel(context.Background())
+	su.supervisorCancel = cancel
+	for _, sup := range su.supervisors {
+		sup := sup
+		su.supervisorWG.Add(1)
+		go func() {
+			defer su.supervisorWG.Done()
+			sup.run(supervisorCtx)
+		}()
+	}
+	if su.daSyncer != nil {
+		su.supervisorWG.Add(1)
+		go func() {
+			defer su.supervisorWG.Done()
+			su.runDASyncer(ctx)
+		}()
 	}
 	return nil
 }
 
+// ChainHealth reports the last observed head, error, and health state for
+// chainID's monitor, as tracked by its chainSupervisor.
+func (su *SupervisorBackend) ChainHealth(chainID types.ChainID) (lastHead uint64, lastErr error, state ChainHealthState) {
+	for _, sup := range su.supervisors {
+		if sup.chainID == chainID {
+			return sup.snapshot()
+		}
+	}
+	return 0, fmt.Errorf("unknown chain %v", chainID), ChainUnhealthy
+}
+
+// runDASyncer drives the DA syncer forward one L1 block at a time until the
+// backend is stopped. As the trust root in Hybrid mode, its errors would be
+// logged rather than treated as fatal so a single missing blob doesn't take
+// down the faster RPC path alongside it.
+//
+// TODO(protocol-quest#288): dead code today - su.daSyncer is always nil,
+// since NewSupervisorBackend refuses to start DA/Hybrid sync mode rather than
+// construct one (da_syncer's batch decoding is unimplemented). Kept, along
+// with the daSyncer field and its Start/Stop wiring, as the shape the DA
+// goroutine will take once decoding lands.
+func (su *SupervisorBackend) runDASyncer(ctx context.Context) {
+	for su.started.Load() {
+		if err := su.daSyncer.Step(ctx); err != nil {
+			su.logger.Error("DA syncer step failed", "err", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Second):
+			}
+		}
+	}
+}
+
 func (su *SupervisorBackend) Stop(ctx context.Context) error {
 	if !su.started.CompareAndSwap(true, false) {
 		return errors.New("already stopped")
 	}
+	// Cancel the chain supervisors and wait for them, and the DA syncer, to
+	// exit before touching the log DBs, so neither a supervisor mid-restart
+	// nor the DA syncer mid-Step can write to a DB we're about to close.
+	if su.supervisorCancel != nil {
+		su.supervisorCancel()
+	}
+	su.supervisorWG.Wait()
+
 	var errs error
 	for _, monitor := range su.chainMonitors {
 		if err := monitor.Stop(); err != nil {
@@ -126,11 +309,11 @@ func (su *SupervisorBackend) Close() error {
 }
 
 func (su *SupervisorBackend) CheckMessage(identifier types.Identifier, payloadHash common.Hash) (types.SafetyLevel, error) {
-	// TODO(protocol-quest#288): hook up to logdb lookup
-	return types.CrossUnsafe, nil
+	chainID := types.ChainIDFromBig(identifier.ChainID.ToBig())
+	return su.resolver.CheckMessage(chainID, uint64(identifier.BlockNumber), uint32(identifier.LogIndex), payloadHash)
 }
 
 func (su *SupervisorBackend) CheckBlock(chainID *hexutil.U256, blockHash common.Hash, blockNumber hexutil.Uint64) (types.SafetyLevel, error) {
-	// TODO(protocol-quest#288): hook up to logdb lookup
-	return types.CrossUnsafe, nil
+	id := types.ChainIDFromBig(chainID.ToBig())
+	return su.resolver.CheckBlock(id, blockHash, uint64(blockNumber))
 }
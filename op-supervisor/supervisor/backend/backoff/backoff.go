@@ -0,0 +1,55 @@
+// Package backoff provides a small exponential-backoff helper for retry
+// loops that need a base delay, a cap, and jitter to avoid many chains
+// reconnecting in lockstep.
+package backoff
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Strategy computes how long to wait before a given retry attempt (0-indexed:
+// attempt 0 is the delay before the first retry).
+type Strategy interface {
+	Duration(attempt int) time.Duration
+}
+
+// Exponential doubles the delay on every attempt, up to Max, and then jitters
+// the result by +/- a fraction of the delay.
+type Exponential struct {
+	Base   time.Duration
+	Max    time.Duration
+	Jitter float64
+}
+
+// NewExponential builds an Exponential strategy. jitter is clamped to [0, 1]
+// and interpreted as +/- that fraction of the un-jittered delay.
+func NewExponential(base, max time.Duration, jitter float64) *Exponential {
+	if jitter < 0 {
+		jitter = 0
+	}
+	if jitter > 1 {
+		jitter = 1
+	}
+	return &Exponential{Base: base, Max: max, Jitter: jitter}
+}
+
+// Duration returns the delay to wait before the given attempt.
+func (e *Exponential) Duration(attempt int) time.Duration {
+	if attempt < 0 {
+		attempt = 0
+	}
+	d := float64(e.Base) * math.Pow(2, float64(attempt))
+	if max := float64(e.Max); e.Max > 0 && d > max {
+		d = max
+	}
+	if e.Jitter > 0 {
+		delta := d * e.Jitter
+		d += (rand.Float64()*2 - 1) * delta
+		if d < 0 {
+			d = 0
+		}
+	}
+	return time.Duration(d)
+}
@@ -0,0 +1,64 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// beaconNodeAPI is a minimal HTTP client for a beacon node's blob sidecar
+// endpoint, adapted to the client interface da_syncer.BeaconNodeClient
+// expects.
+type beaconNodeAPI struct {
+	baseURL string
+}
+
+func newBeaconNodeAPI(baseURL string) *beaconNodeAPI {
+	return &beaconNodeAPI{baseURL: baseURL}
+}
+
+func (b *beaconNodeAPI) BeaconBlobSideCars(ctx context.Context, fetchAllSidecars bool, slot uint64, hashes []eth.IndexedBlobHash) (eth.BlobSidecars, error) {
+	url := fmt.Sprintf("%s/eth/v1/beacon/blob_sidecars/%d", b.baseURL, slot)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return eth.BlobSidecars{}, fmt.Errorf("failed to build blob sidecar request: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return eth.BlobSidecars{}, fmt.Errorf("failed to fetch blob sidecars for slot %d: %w", slot, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return eth.BlobSidecars{}, fmt.Errorf("beacon node returned status %d for slot %d", resp.StatusCode, slot)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return eth.BlobSidecars{}, fmt.Errorf("failed to read blob sidecars response for slot %d: %w", slot, err)
+	}
+	var sidecars eth.BlobSidecars
+	if err := sidecars.UnmarshalJSON(body); err != nil {
+		return eth.BlobSidecars{}, fmt.Errorf("failed to decode blob sidecars for slot %d: %w", slot, err)
+	}
+	return sidecars, nil
+}
+
+// httpGet performs a simple GET request, used by the blobscan fallback
+// client for fetching individual blobs by hash.
+func httpGet(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request for %v: %w", url, err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %v: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("got status %d fetching %v", resp.StatusCode, url)
+	}
+	return io.ReadAll(resp.Body)
+}
@@ -0,0 +1,90 @@
+package da_syncer
+
+import (
+	"container/heap"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// decodedBatch is a batch decoded from batcher calldata or a blob, tagged
+// with the L1 position it was found at so BatchQueue can order batches from
+// different transactions/blocks the way the batcher submitted them.
+type decodedBatch struct {
+	l1BlockNumber uint64
+	l1TxIndex     int
+	chainID       uint64
+	batch         *derivedBatch
+}
+
+// derivedLog is the minimal per-log information DASyncer needs to append into
+// a chain's LogStore; it mirrors the arguments db.DB.AddLog already takes.
+type derivedLog struct {
+	txHash common.Hash
+	time   uint64
+}
+
+// derivedBatch is the per-chain block/log stream extracted from a single
+// batch. The exact block/receipt representation mirrors what
+// source.ChainMonitor already appends to db.DB, so DASyncer can feed both the
+// same way.
+type derivedBatch struct {
+	blocks []eth.L2BlockRef
+	logs   [][]derivedLog
+}
+
+// batchHeap orders decodedBatches by L1 block number then transaction index,
+// which is the order the batcher inbox actually committed them in.
+type batchHeap []*decodedBatch
+
+func (h batchHeap) Len() int { return len(h) }
+func (h batchHeap) Less(i, j int) bool {
+	if h[i].l1BlockNumber != h[j].l1BlockNumber {
+		return h[i].l1BlockNumber < h[j].l1BlockNumber
+	}
+	return h[i].l1TxIndex < h[j].l1TxIndex
+}
+func (h batchHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *batchHeap) Push(x any)   { *h = append(*h, x.(*decodedBatch)) }
+func (h *batchHeap) Pop() any {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
+
+// BatchQueue buffers decoded batches out of L1 order (blobs and calldata for
+// different chains can be decoded concurrently) and releases them to the
+// caller in L1 block/tx-index order, which is required for DASyncer to
+// Rewind/append into db.DB deterministically.
+type BatchQueue struct {
+	h batchHeap
+}
+
+// NewBatchQueue returns an empty BatchQueue.
+func NewBatchQueue() *BatchQueue {
+	return &BatchQueue{}
+}
+
+// Add inserts a decoded batch into the queue.
+func (q *BatchQueue) Add(l1BlockNumber uint64, l1TxIndex int, chainID uint64, batch *derivedBatch) {
+	heap.Push(&q.h, &decodedBatch{
+		l1BlockNumber: l1BlockNumber,
+		l1TxIndex:     l1TxIndex,
+		chainID:       chainID,
+		batch:         batch,
+	})
+}
+
+// Len returns the number of batches currently buffered.
+func (q *BatchQueue) Len() int {
+	return q.h.Len()
+}
+
+// Pop removes and returns the earliest (by L1 position) buffered batch. It
+// panics if the queue is empty; callers must check Len first.
+func (q *BatchQueue) Pop() (l1BlockNumber uint64, chainID uint64, batch *derivedBatch) {
+	item := heap.Pop(&q.h).(*decodedBatch)
+	return item.l1BlockNumber, item.chainID, item.batch
+}
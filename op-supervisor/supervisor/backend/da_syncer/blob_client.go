@@ -0,0 +1,118 @@
+package da_syncer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// BlobClient fetches EIP-4844 blobs referenced by batcher transactions. It is
+// implemented by BeaconNodeClient (talks to a beacon node's blob sidecar API)
+// and BlobScanClient (talks to a blob explorer, useful once a beacon node has
+// pruned the blob), and by BlobClientList, which round-robins across several
+// of either.
+type BlobClient interface {
+	// GetBlobs fetches the blobs referenced by hashes that were included in
+	// the block identified by ref. The returned slice is in the same order
+	// as hashes.
+	GetBlobs(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error)
+}
+
+// BeaconNodeClient fetches blobs from a beacon node's blob sidecar endpoint.
+type BeaconNodeClient struct {
+	cl client
+}
+
+type client interface {
+	BeaconBlobSideCars(ctx context.Context, fetchAllSidecars bool, slot uint64, hashes []eth.IndexedBlobHash) (eth.BlobSidecars, error)
+}
+
+// NewBeaconNodeClient wraps cl, the beacon-node API client, as a BlobClient.
+func NewBeaconNodeClient(cl client) *BeaconNodeClient {
+	return &BeaconNodeClient{cl: cl}
+}
+
+func (b *BeaconNodeClient) GetBlobs(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error) {
+	sidecars, err := b.cl.BeaconBlobSideCars(ctx, false, ref.Time, hashes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch blob sidecars for block %v: %w", ref, err)
+	}
+	byIndex := make(map[uint64]*eth.Blob, len(sidecars.Data))
+	for _, sidecar := range sidecars.Data {
+		sidecar := sidecar // local copy: safe to take its address below
+		byIndex[uint64(sidecar.Index)] = &sidecar.Blob
+	}
+	out := make([]*eth.Blob, len(hashes))
+	for i, h := range hashes {
+		blob, ok := byIndex[h.Index]
+		if !ok {
+			return nil, fmt.Errorf("beacon node response for block %v is missing blob index %d (hash %v)", ref, h.Index, h.Hash)
+		}
+		out[i] = blob
+	}
+	return out, nil
+}
+
+// BlobScanClient fetches blobs from a blobscan-compatible HTTP API, used as a
+// fallback once the beacon node chain has pruned the blob (blobs only live a
+// few epochs on beacon nodes).
+type BlobScanClient struct {
+	baseURL string
+	getter  func(ctx context.Context, url string) ([]byte, error)
+}
+
+// NewBlobScanClient constructs a BlobScanClient pointed at baseURL, e.g.
+// "https://api.blobscan.com".
+func NewBlobScanClient(baseURL string, getter func(ctx context.Context, url string) ([]byte, error)) *BlobScanClient {
+	return &BlobScanClient{baseURL: baseURL, getter: getter}
+}
+
+func (b *BlobScanClient) GetBlobs(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error) {
+	out := make([]*eth.Blob, len(hashes))
+	for i, h := range hashes {
+		data, err := b.getter(ctx, fmt.Sprintf("%s/blobs/%s", b.baseURL, common.Hash(h.Hash).Hex()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch blob %v from blobscan: %w", h.Hash, err)
+		}
+		blob := new(eth.Blob)
+		if err := blob.UnmarshalJSON(data); err != nil {
+			return nil, fmt.Errorf("failed to decode blob %v from blobscan: %w", h.Hash, err)
+		}
+		out[i] = blob
+	}
+	return out, nil
+}
+
+// BlobClientList round-robins GetBlobs calls across multiple BlobClients,
+// trying the next one whenever the current one errors, so a single missing
+// sidecar on one provider doesn't stall the syncer.
+type BlobClientList struct {
+	clients []BlobClient
+	next    int
+}
+
+// NewBlobClientList builds a BlobClientList over clients, which must be
+// non-empty.
+func NewBlobClientList(clients ...BlobClient) (*BlobClientList, error) {
+	if len(clients) == 0 {
+		return nil, fmt.Errorf("need at least one blob client")
+	}
+	return &BlobClientList{clients: clients}, nil
+}
+
+func (l *BlobClientList) GetBlobs(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error) {
+	var errs error
+	for i := 0; i < len(l.clients); i++ {
+		idx := (l.next + i) % len(l.clients)
+		blobs, err := l.clients[idx].GetBlobs(ctx, ref, hashes)
+		if err == nil {
+			l.next = (idx + 1) % len(l.clients)
+			return blobs, nil
+		}
+		errs = errors.Join(errs, fmt.Errorf("client %d: %w", idx, err))
+	}
+	return nil, fmt.Errorf("all blob clients failed to fetch blobs for block %v: %w", ref, errs)
+}
@@ -0,0 +1,34 @@
+package da_syncer
+
+import (
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// decodeCalldataBatch decodes a batcher transaction's raw calldata into a
+// derivedBatch.
+//
+// TODO(protocol-quest#288): this must reuse op-node's derivation pipeline
+// (channel bank, batch queue, span-batch decoding) rather than reimplement
+// it, so DA-mode and RPC-mode can never silently disagree on what a batch
+// means. Returning an error until that's wired in is preferable to writing
+// possibly-wrong blocks into a chain's log DB.
+func decodeCalldataBatch(data []byte) (*derivedBatch, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	return nil, fmt.Errorf("calldata batch decoding is not yet implemented")
+}
+
+// decodeBlobBatch decodes a set of EIP-4844 blobs (as fetched by a
+// BlobClient) into a derivedBatch.
+//
+// TODO(protocol-quest#288): see decodeCalldataBatch; span-batch blobs need
+// the same op-node-derivation-pipeline treatment.
+func decodeBlobBatch(blobs []*eth.Blob) (*derivedBatch, error) {
+	if len(blobs) == 0 {
+		return nil, nil
+	}
+	return nil, fmt.Errorf("blob batch decoding is not yet implemented")
+}
@@ -0,0 +1,55 @@
+package backend
+
+import (
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// dbLogDB adapts a *db.DB to the LogDB interface. db.DB's own MessageAt and
+// MessagesInBlock return db's own Message/MessageRef types rather than
+// backend's: db.DB can't return backend.Message directly without an import
+// cycle, since backend already imports db. dbLogDB is the seam that converts
+// between the two, so AddChain can register a real *db.DB instead of only
+// the fakeLogDB the tests use.
+type dbLogDB struct {
+	db *db.DB
+}
+
+// newDBLogDB wraps d as a LogDB.
+func newDBLogDB(d *db.DB) *dbLogDB {
+	return &dbLogDB{db: d}
+}
+
+func (a *dbLogDB) MessageAt(blockNumber uint64, logIndex uint32) (Message, bool, error) {
+	msg, ok, err := a.db.MessageAt(blockNumber, logIndex)
+	if err != nil || !ok {
+		return Message{}, ok, err
+	}
+	return convertDBMessage(msg), true, nil
+}
+
+func (a *dbLogDB) MessagesInBlock(blockHash common.Hash, blockNumber uint64) ([]Message, error) {
+	msgs, err := a.db.MessagesInBlock(blockHash, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Message, len(msgs))
+	for i, msg := range msgs {
+		out[i] = convertDBMessage(msg)
+	}
+	return out, nil
+}
+
+// convertDBMessage copies a db.Message into the equivalent backend.Message.
+//
+// TODO(protocol-quest#288): db.DB's write path (LogStore.AddLog) only ever
+// records a log's payload hash, never the executing-message refs it depends
+// on, so the msg.Deps copied here is always empty. See the LogDB doc comment
+// for what that means for cross-chain promotion.
+func convertDBMessage(msg db.Message) Message {
+	deps := make([]MessageRef, len(msg.Deps))
+	for i, d := range msg.Deps {
+		deps[i] = MessageRef{ChainID: d.ChainID, BlockNumber: d.BlockNumber, LogIndex: d.LogIndex}
+	}
+	return Message{PayloadHash: msg.PayloadHash, BlockHash: msg.BlockHash, Deps: deps}
+}
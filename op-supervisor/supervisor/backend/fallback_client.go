@@ -0,0 +1,324 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// defaultFallbackThreshold is the number of non-application failures within
+// failureWindow that trigger a switch to the next endpoint.
+const defaultFallbackThreshold = 5
+
+// failureWindow is the sliding window over which failures are counted towards FallbackThreshold.
+const failureWindow = time.Minute
+
+// defaultHeadLagThreshold is how many blocks behind the furthest-ahead peer
+// endpoint the active endpoint may fall before headCheckInterval's quorum
+// check switches away from it, even though it hasn't failed outright (e.g.
+// a node that's up but stuck syncing).
+const defaultHeadLagThreshold = 5
+
+// headCheckInterval is how often the active endpoint's head is compared
+// against its peers' heads.
+const headCheckInterval = 30 * time.Second
+
+// FallbackMetrics is a new, optional extension of the supervisor's existing
+// Metrics interface (see HealthMetrics for the same pattern), type-asserted
+// where a FallbackClient is constructed - not an addition to Metrics itself,
+// so a Metrics implementation that doesn't care about fallback health isn't
+// forced to grow these methods.
+type FallbackMetrics interface {
+	RecordFallbackEndpointIndex(chainID types.ChainID, idx int)
+	RecordFallbackSwitch(chainID types.ChainID)
+	RecordFallbackFailure(chainID types.ChainID, idx int)
+}
+
+// endpoint tracks a single underlying RPC client and its recent failures.
+type endpoint struct {
+	url    string
+	client client.RPC
+
+	mu       sync.Mutex
+	failures []time.Time
+}
+
+func (e *endpoint) recordFailure(now time.Time) int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	cutoff := now.Add(-failureWindow)
+	live := e.failures[:0]
+	for _, t := range e.failures {
+		if t.After(cutoff) {
+			live = append(live, t)
+		}
+	}
+	live = append(live, now)
+	e.failures = live
+	return len(e.failures)
+}
+
+func (e *endpoint) resetFailures() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.failures = nil
+}
+
+// FallbackClient wraps a list of client.RPC endpoints for the same chain and
+// transparently switches to the next healthy one when the current one starts
+// failing. Only transport-level failures count towards the switch threshold;
+// legitimate RPC responses (e.g. *rpc.Error, reverts) never trigger a switch.
+type FallbackClient struct {
+	logger  log.Logger
+	chainID types.ChainID
+	m       FallbackMetrics
+
+	endpoints []*endpoint
+	currentIdx atomic.Int64
+
+	threshold int
+
+	switching atomic.Bool
+
+	stopCh    chan struct{}
+	closeOnce sync.Once
+}
+
+var _ client.RPC = (*FallbackClient)(nil)
+
+// NewFallbackClient builds a FallbackClient over the given endpoints. The first
+// endpoint is used as the active one. threshold <= 0 selects defaultFallbackThreshold.
+func NewFallbackClient(logger log.Logger, chainID types.ChainID, m FallbackMetrics, urls []string, clients []client.RPC, threshold int) (*FallbackClient, error) {
+	if len(urls) != len(clients) {
+		return nil, fmt.Errorf("have %d urls but %d clients", len(urls), len(clients))
+	}
+	if len(clients) == 0 {
+		return nil, errors.New("need at least one RPC endpoint")
+	}
+	if threshold <= 0 {
+		threshold = defaultFallbackThreshold
+	}
+	endpoints := make([]*endpoint, len(clients))
+	for i, c := range clients {
+		endpoints[i] = &endpoint{url: urls[i], client: c}
+	}
+	fc := &FallbackClient{
+		logger:    logger,
+		chainID:   chainID,
+		m:         m,
+		endpoints: endpoints,
+		threshold: threshold,
+		stopCh:    make(chan struct{}),
+	}
+	if m != nil {
+		m.RecordFallbackEndpointIndex(chainID, 0)
+	}
+	if len(endpoints) > 1 {
+		go fc.monitorHeads()
+	}
+	return fc, nil
+}
+
+func (f *FallbackClient) current() *endpoint {
+	idx := f.currentIdx.Load()
+	return f.endpoints[idx]
+}
+
+// CallContext proxies to the active endpoint, triggering a fallback check on
+// transport errors.
+func (f *FallbackClient) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	idx := f.currentIdx.Load()
+	ep := f.endpoints[idx]
+	err := ep.client.CallContext(ctx, result, method, args...)
+	if err != nil && shouldCountAsFailure(err) {
+		f.onFailure(ctx, int(idx), ep)
+	}
+	return err
+}
+
+// BatchCallContext proxies to the active endpoint, triggering a fallback check on
+// transport errors.
+func (f *FallbackClient) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	idx := f.currentIdx.Load()
+	ep := f.endpoints[idx]
+	err := ep.client.BatchCallContext(ctx, b)
+	if err != nil && shouldCountAsFailure(err) {
+		f.onFailure(ctx, int(idx), ep)
+	}
+	return err
+}
+
+// EthSubscribe proxies to the active endpoint at call time. It does not
+// resubscribe an already-open subscription when tryFallback later switches
+// the active index: FallbackClient has no way to signal source.ChainMonitor
+// (not this package, and not touched by this change) that its subscription
+// is now pinned to an endpoint that's no longer active. In practice a head
+// subscription opened before a switch stays on the old endpoint until
+// ChainMonitor's own reconnect logic notices the subscription died and calls
+// EthSubscribe again, which then lands on the new active endpoint. Closing
+// this gap requires either a callback FallbackClient can invoke on switch or
+// ChainMonitor polling FallbackClient's active endpoint itself.
+func (f *FallbackClient) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	idx := f.currentIdx.Load()
+	ep := f.endpoints[idx]
+	sub, err := ep.client.EthSubscribe(ctx, channel, args...)
+	if err != nil && shouldCountAsFailure(err) {
+		f.onFailure(ctx, int(idx), ep)
+	}
+	return sub, err
+}
+
+func (f *FallbackClient) Close() {
+	f.closeOnce.Do(func() { close(f.stopCh) })
+	for _, ep := range f.endpoints {
+		ep.client.Close()
+	}
+}
+
+// shouldCountAsFailure reports whether err represents a transport-level
+// problem (timeouts, connection errors, 5xx) rather than a legitimate RPC
+// response such as a contract revert.
+func shouldCountAsFailure(err error) bool {
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		// A well-formed JSON-RPC error response is the server answering correctly,
+		// even if the answer is an application-level error.
+		return false
+	}
+	return true
+}
+
+func (f *FallbackClient) onFailure(ctx context.Context, idx int, ep *endpoint) {
+	count := ep.recordFailure(time.Now())
+	if f.m != nil {
+		f.m.RecordFallbackFailure(f.chainID, idx)
+	}
+	if count < f.threshold {
+		return
+	}
+	f.tryFallback(ctx, idx)
+}
+
+// tryFallback attempts to move the active index to the next endpoint whose
+// chain ID matches and whose head is fresh. Only one switch attempt runs at a
+// time; concurrent callers simply return once a switch is already underway.
+func (f *FallbackClient) tryFallback(ctx context.Context, failedIdx int) {
+	if !f.switching.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		defer f.switching.Store(false)
+		next := (failedIdx + 1) % len(f.endpoints)
+		if next == failedIdx {
+			return
+		}
+		ep := f.endpoints[next]
+		if err := f.validateEndpoint(ctx, ep, failedIdx); err != nil {
+			f.logger.Warn("fallback candidate endpoint failed validation", "url", ep.url, "err", err)
+			return
+		}
+		if f.currentIdx.CompareAndSwap(int64(failedIdx), int64(next)) {
+			ep.resetFailures()
+			f.logger.Warn("switched RPC endpoint after repeated failures", "chain", f.chainID, "from", failedIdx, "to", next, "url", ep.url)
+			if f.m != nil {
+				f.m.RecordFallbackEndpointIndex(f.chainID, next)
+				f.m.RecordFallbackSwitch(f.chainID)
+			}
+		}
+	}()
+}
+
+// validateEndpoint confirms the candidate endpoint is on the right chain and
+// has a reasonably fresh head - within defaultHeadLagThreshold blocks of the
+// endpoint it would replace - before it becomes active.
+func (f *FallbackClient) validateEndpoint(ctx context.Context, ep *endpoint, prevIdx int) error {
+	var chainIDHex hexutil.Big
+	if err := ep.client.CallContext(ctx, &chainIDHex, "eth_chainId"); err != nil {
+		return fmt.Errorf("failed to query chain id: %w", err)
+	}
+	candidateChainID := types.ChainIDFromBig((*big.Int)(&chainIDHex))
+	if candidateChainID != f.chainID {
+		return fmt.Errorf("endpoint reports chain id %v, expected %v", candidateChainID, f.chainID)
+	}
+	candidateHead, err := queryHeadNumber(ctx, ep.client)
+	if err != nil {
+		return fmt.Errorf("failed to query head block: %w", err)
+	}
+	if prevHead, err := queryHeadNumber(ctx, f.endpoints[prevIdx].client); err == nil &&
+		prevHead > candidateHead && prevHead-candidateHead > defaultHeadLagThreshold {
+		return fmt.Errorf("endpoint head %d lags previous active endpoint head %d by more than %d blocks", candidateHead, prevHead, defaultHeadLagThreshold)
+	}
+	return nil
+}
+
+// queryHeadNumber fetches the endpoint's latest block number.
+func queryHeadNumber(ctx context.Context, c client.RPC) (uint64, error) {
+	var head *struct {
+		Number hexutil.Uint64 `json:"number"`
+	}
+	if err := c.CallContext(ctx, &head, "eth_getBlockByNumber", "latest", false); err != nil {
+		return 0, err
+	}
+	if head == nil {
+		return 0, errors.New("endpoint returned no head block")
+	}
+	return uint64(head.Number), nil
+}
+
+// monitorHeads periodically compares the active endpoint's head against its
+// peers' until Close is called, independently of the failure-count-based
+// switch in onFailure.
+func (f *FallbackClient) monitorHeads() {
+	ticker := time.NewTicker(headCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-f.stopCh:
+			return
+		case <-ticker.C:
+			f.checkHeadLag()
+		}
+	}
+}
+
+// checkHeadLag switches away from the active endpoint if it has fallen more
+// than defaultHeadLagThreshold blocks behind the furthest-ahead peer, even
+// though no transport failures have been recorded against it.
+func (f *FallbackClient) checkHeadLag() {
+	idx := int(f.currentIdx.Load())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	currentHead, err := queryHeadNumber(ctx, f.endpoints[idx].client)
+	if err != nil {
+		// A failing active endpoint is already handled by onFailure via the
+		// normal call path; nothing further to do here.
+		return
+	}
+	var bestPeer uint64
+	for i, ep := range f.endpoints {
+		if i == idx {
+			continue
+		}
+		if head, err := queryHeadNumber(ctx, ep.client); err == nil && head > bestPeer {
+			bestPeer = head
+		}
+	}
+	if bestPeer > currentHead && bestPeer-currentHead > defaultHeadLagThreshold {
+		f.logger.Warn("active RPC endpoint head lags quorum peers, switching",
+			"chain", f.chainID, "idx", idx, "head", currentHead, "peerHead", bestPeer)
+		// tryFallback validates the candidate asynchronously; hand it a fresh,
+		// uncancelled context rather than the one we're about to tear down.
+		f.tryFallback(context.Background(), idx)
+	}
+}
@@ -0,0 +1,57 @@
+package backend
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+// chainHeadState is the most recent unsafe/safe/finalized block numbers
+// SupervisorBackend has observed for a single chain.
+type chainHeadState struct {
+	unsafe, safe, finalized uint64
+}
+
+// headTracker is the default HeadTracker backing SafetyResolver: a plain
+// in-memory map of per-chain head pointers. In RPC mode it's kept current by
+// chainSupervisor polling the chain's log DB checkpoint (see
+// chainSupervisor.pollHeads); DA mode will need da_syncer.DASyncer to call
+// SetHeads the same way once it can actually decode batches
+// (TODO(protocol-quest#288)).
+type headTracker struct {
+	mu    sync.RWMutex
+	heads map[types.ChainID]chainHeadState
+}
+
+func newHeadTracker() *headTracker {
+	return &headTracker{heads: make(map[types.ChainID]chainHeadState)}
+}
+
+// SetHeads records the latest unsafe/safe/finalized block numbers observed
+// for chainID.
+func (h *headTracker) SetHeads(chainID types.ChainID, unsafe, safe, finalized uint64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.heads[chainID] = chainHeadState{unsafe: unsafe, safe: safe, finalized: finalized}
+}
+
+// LocalSafetyLevel implements HeadTracker.
+func (h *headTracker) LocalSafetyLevel(chainID types.ChainID, blockNumber uint64) (types.SafetyLevel, error) {
+	h.mu.RLock()
+	state, ok := h.heads[chainID]
+	h.mu.RUnlock()
+	if !ok {
+		return types.Unsafe, fmt.Errorf("no head state recorded yet for chain %v", chainID)
+	}
+	switch {
+	case blockNumber <= state.finalized:
+		return types.Finalized, nil
+	case blockNumber <= state.safe:
+		return types.Safe, nil
+	case blockNumber <= state.unsafe:
+		return types.Unsafe, nil
+	default:
+		return types.Unsafe, fmt.Errorf("block %v is ahead of chain %v's known unsafe head %v", blockNumber, chainID, state.unsafe)
+	}
+}
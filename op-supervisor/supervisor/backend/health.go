@@ -0,0 +1,223 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/backoff"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/source"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// defaultBackoffBase/Max/Jitter are the backoff settings NewSupervisorBackend
+// gives every chain's supervisor; no config setting overrides them.
+const (
+	defaultBackoffBase   = 500 * time.Millisecond
+	defaultBackoffMax    = 30 * time.Second
+	defaultBackoffJitter = 0.2
+)
+
+// headPollInterval is how often a chainSupervisor re-reads its chain's log DB
+// checkpoint to refresh the HeadTracker's view of that chain's unsafe head.
+const headPollInterval = 2 * time.Second
+
+// staleHeadTimeout is how long a chain's log DB checkpoint may go without
+// advancing before pollHeads treats the monitor as stuck and run restarts it
+// with backoff, even though ChainMonitor.Start returned no error.
+const staleHeadTimeout = 2 * time.Minute
+
+// errHeadStalled is recorded as a chain's last error when pollHeads finds its
+// log DB checkpoint stalled.
+var errHeadStalled = errors.New("chain monitor head stalled")
+
+// headSource is the subset of db.DB chainSupervisor polls to learn the
+// chain's latest locally-observed block. ChainMonitor itself exposes no head
+// accessor, so this is the only head signal available in RPC mode.
+type headSource interface {
+	ClosestBlockInfo(blockNum uint64) (uint64, common.Hash, error)
+}
+
+// ChainHealthState summarizes whether a chain's monitor is currently up, or
+// backing off after a failed (re)start.
+type ChainHealthState string
+
+const (
+	ChainHealthy   ChainHealthState = "healthy"
+	ChainUnhealthy ChainHealthState = "unhealthy"
+)
+
+// HealthMetrics lets a Metrics implementation optionally record chain health
+// transitions, following the same "type-assert an optional extension
+// interface" pattern as FallbackMetrics.
+type HealthMetrics interface {
+	RecordChainHealth(chainID types.ChainID, state ChainHealthState, consecutiveFailures int)
+}
+
+// chainSupervisor restarts a single chain's ChainMonitor with exponential
+// backoff whenever (re)starting it fails, and also after it has started
+// successfully but its chain's log DB checkpoint stops advancing
+// (pollHeads), so a connection lost after startup gets the same coordinated
+// backoff as a failed dial. It tracks the chain's most recently observed
+// health for SupervisorBackend.ChainHealth.
+//
+// TODO(protocol-quest#288): staleness of the log DB checkpoint is a proxy
+// for liveness, not a direct signal from ChainMonitor, so a chain that's
+// genuinely idle (no new blocks) looks identical to one that's stuck.
+// Prefer a real liveness signal from ChainMonitor once it exposes one.
+type chainSupervisor struct {
+	logger   log.Logger
+	chainID  types.ChainID
+	monitor  *source.ChainMonitor
+	strategy backoff.Strategy
+	m        HealthMetrics
+	heads    *headTracker
+	logDB    headSource
+
+	mu                  sync.RWMutex
+	lastHead            uint64
+	lastErr             error
+	state               ChainHealthState
+	consecutiveFailures int
+}
+
+func newChainSupervisor(logger log.Logger, chainID types.ChainID, monitor *source.ChainMonitor, strategy backoff.Strategy, m HealthMetrics, heads *headTracker, logDB headSource) *chainSupervisor {
+	return &chainSupervisor{
+		logger:   logger,
+		chainID:  chainID,
+		monitor:  monitor,
+		strategy: strategy,
+		m:        m,
+		heads:    heads,
+		logDB:    logDB,
+		state:    ChainUnhealthy,
+	}
+}
+
+// run starts the monitor, retrying with backoff on failure, for as long as
+// ctx is alive. A successful start isn't the end of supervision: run then
+// waits on pollHeads for a sign the chain has stalled, stops the monitor,
+// and goes back to retrying with the same backoff strategy - so a
+// connection lost well after startup is retried exactly like a failed dial,
+// rather than becoming "the monitor's problem" forever.
+func (s *chainSupervisor) run(ctx context.Context) {
+	for {
+		err := s.monitor.Start()
+		if err != nil {
+			s.recordFailure(err)
+			attempt := s.consecutiveFailuresSnapshot() - 1
+			delay := s.strategy.Duration(attempt)
+			s.logger.Warn("chain monitor failed to start, retrying with backoff",
+				"chain", s.chainID, "attempt", attempt+1, "delay", delay, "err", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(delay):
+			}
+			continue
+		}
+		s.recordSuccess()
+		if stalled := s.pollHeads(ctx); !stalled {
+			// ctx was cancelled rather than the chain stalling; shut down.
+			return
+		}
+		if err := s.monitor.Stop(); err != nil {
+			s.logger.Warn("failed to stop stalled chain monitor before restart", "chain", s.chainID, "err", err)
+		}
+		s.recordFailure(errHeadStalled)
+		delay := s.strategy.Duration(s.consecutiveFailuresSnapshot() - 1)
+		s.logger.Warn("restarting stalled chain monitor with backoff", "chain", s.chainID, "delay", delay)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (s *chainSupervisor) recordSuccess() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state = ChainHealthy
+	s.lastErr = nil
+	s.consecutiveFailures = 0
+	if s.m != nil {
+		s.m.RecordChainHealth(s.chainID, s.state, s.consecutiveFailures)
+	}
+}
+
+func (s *chainSupervisor) recordFailure(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state = ChainUnhealthy
+	s.lastErr = err
+	s.consecutiveFailures++
+	if s.m != nil {
+		s.m.RecordChainHealth(s.chainID, s.state, s.consecutiveFailures)
+	}
+}
+
+func (s *chainSupervisor) consecutiveFailuresSnapshot() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.consecutiveFailures
+}
+
+func (s *chainSupervisor) setLastHead(head uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastHead = head
+}
+
+// pollHeads periodically re-reads the chain's log DB checkpoint, republishes
+// it to heads and s.lastHead, and reports whether the chain looks stalled:
+// it returns true once the checkpoint has gone staleHeadTimeout without
+// advancing, so run can restart the monitor, or false if ctx was cancelled
+// first.
+//
+// TODO(protocol-quest#288): only the unsafe head is derivable this way; safe
+// and finalized heads need L1 finality signals ChainMonitor doesn't expose
+// yet, so they're left at 0 (i.e. no block after genesis is ever locally
+// Safe/Finalized) until it does.
+func (s *chainSupervisor) pollHeads(ctx context.Context) bool {
+	ticker := time.NewTicker(headPollInterval)
+	defer ticker.Stop()
+	var lastAdvance time.Time
+	var lastUnsafe uint64
+	seen := false
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+			unsafe, _, err := s.logDB.ClosestBlockInfo(math.MaxUint64)
+			if err != nil {
+				continue
+			}
+			s.heads.SetHeads(s.chainID, unsafe, 0, 0)
+			s.setLastHead(unsafe)
+			if !seen || unsafe != lastUnsafe {
+				seen = true
+				lastUnsafe = unsafe
+				lastAdvance = time.Now()
+				continue
+			}
+			if time.Since(lastAdvance) > staleHeadTimeout {
+				s.logger.Warn("chain monitor head has stalled, restarting",
+					"chain", s.chainID, "head", unsafe, "stalledFor", time.Since(lastAdvance))
+				return true
+			}
+		}
+	}
+}
+
+// snapshot returns the state ChainHealth reports.
+func (s *chainSupervisor) snapshot() (lastHead uint64, lastErr error, state ChainHealthState) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lastHead, s.lastErr, s.state
+}
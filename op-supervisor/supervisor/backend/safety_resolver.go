@@ -0,0 +1,240 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrUnknownChain is returned when a SafetyResolver is asked about a chain it
+// has no LogDB registered for.
+var ErrUnknownChain = errors.New("unknown chain")
+
+// ErrUnknownMessage is returned when an identifier doesn't resolve to any log
+// in the chain's LogDB.
+var ErrUnknownMessage = errors.New("unknown message")
+
+// ErrPayloadMismatch is returned by CheckMessage when the payload hash stored
+// at the identifier's position doesn't match what the caller supplied.
+var ErrPayloadMismatch = errors.New("payload hash does not match")
+
+// MessageRef points at a single executing-message log entry: the chain it was
+// emitted on, and its position within that chain's log DB.
+type MessageRef struct {
+	ChainID     types.ChainID
+	BlockNumber uint64
+	LogIndex    uint32
+}
+
+// Message is everything the SafetyResolver needs to know about a single log
+// entry: its payload hash (to validate CheckMessage callers) and the
+// initiating messages it depends on, if it is itself an executing message.
+type Message struct {
+	PayloadHash common.Hash
+	BlockHash   common.Hash
+	Deps        []MessageRef
+}
+
+// LogDB is the subset of db.DB the SafetyResolver reads from; tests
+// substitute an in-memory fake.
+//
+// TODO(protocol-quest#288): db.DB's write path (LogStore.AddLog, used by both
+// source.ChainMonitor and da_syncer.DASyncer) carries no executing-message
+// dependency information today, so no real LogDB can populate Message.Deps
+// yet - every message comes back with Deps == nil regardless of whether it's
+// actually an executing message. depsSafeAtLeast therefore treats every
+// message as dependency-free and CheckMessage/CheckBlock promote it to its
+// Cross* level unconditionally. Closing this requires extending the write
+// path to thread dependency refs through to storage before MessageAt can
+// return real ones.
+type LogDB interface {
+	// MessageAt returns the message at the given position in this chain's log,
+	// or ok=false if no such log exists yet.
+	MessageAt(blockNumber uint64, logIndex uint32) (msg Message, ok bool, err error)
+	// MessagesInBlock returns every message logged in the block identified by
+	// hash and number, or an error if the block isn't known.
+	MessagesInBlock(blockHash common.Hash, blockNumber uint64) ([]Message, error)
+}
+
+// HeadTracker reports the local safety level of a block on a chain, i.e. the
+// level derived purely from that chain's own head pointers (unsafe/safe/
+// finalized head), before any cross-chain promotion. SafetyResolver takes any
+// HeadTracker; the backend uses headTracker, which chainSupervisor keeps
+// current from each chain's log DB in RPC mode. da_syncer.DASyncer does not
+// feed a HeadTracker.
+type HeadTracker interface {
+	LocalSafetyLevel(chainID types.ChainID, blockNumber uint64) (types.SafetyLevel, error)
+}
+
+// SafetyResolver computes cross-chain safety levels by consulting every
+// chain's LogDB. A message's local level (Unsafe/Safe/Finalized) comes from
+// HeadTracker; it is promoted to the matching Cross* level only once every
+// initiating message it transitively depends on is confirmed to be at least
+// as safe in its own chain.
+//
+// Against a real *db.DB (see dbLogDB), this promotion is not yet meaningful:
+// LogDB's doc comment explains why every message's Deps comes back empty, so
+// depsSafeAtLeast has nothing to check and CheckMessage/CheckBlock promote
+// every message to its Cross* level unconditionally - the same behavior as
+// the stub this replaced. Callers should not treat a Cross* result as having
+// verified cross-chain dependencies yet.
+type SafetyResolver struct {
+	logDBs map[types.ChainID]LogDB
+	heads  HeadTracker
+}
+
+// NewSafetyResolver constructs a SafetyResolver with no chains registered;
+// call AddChain for each chain the supervisor is following.
+func NewSafetyResolver(heads HeadTracker) *SafetyResolver {
+	return &SafetyResolver{
+		logDBs: make(map[types.ChainID]LogDB),
+		heads:  heads,
+	}
+}
+
+// AddChain registers the LogDB for chainID. Calling it twice for the same
+// chain replaces the previous LogDB.
+func (r *SafetyResolver) AddChain(chainID types.ChainID, logDB LogDB) {
+	r.logDBs[chainID] = logDB
+}
+
+// CheckMessage looks up identifier in its chain's LogDB, verifies
+// payloadHash matches what was recorded, and returns the resulting safety
+// level: the identifier's local level, promoted to the matching Cross* level
+// if every message it depends on (transitively) is at least as safe in its
+// own chain.
+func (r *SafetyResolver) CheckMessage(chainID types.ChainID, blockNumber uint64, logIndex uint32, payloadHash common.Hash) (types.SafetyLevel, error) {
+	var zero types.SafetyLevel
+	logDB, ok := r.logDBs[chainID]
+	if !ok {
+		return zero, fmt.Errorf("%w: %v", ErrUnknownChain, chainID)
+	}
+	msg, ok, err := logDB.MessageAt(blockNumber, logIndex)
+	if err != nil {
+		return zero, fmt.Errorf("failed to look up message at chain %v block %v log %v: %w", chainID, blockNumber, logIndex, err)
+	}
+	if !ok {
+		return zero, fmt.Errorf("%w: chain %v block %v log %v", ErrUnknownMessage, chainID, blockNumber, logIndex)
+	}
+	if msg.PayloadHash != payloadHash {
+		return zero, fmt.Errorf("%w: chain %v block %v log %v", ErrPayloadMismatch, chainID, blockNumber, logIndex)
+	}
+	local, err := r.heads.LocalSafetyLevel(chainID, blockNumber)
+	if err != nil {
+		return zero, fmt.Errorf("failed to get local safety level for chain %v block %v: %w", chainID, blockNumber, err)
+	}
+	if len(msg.Deps) == 0 {
+		return crossLevel(local), nil
+	}
+	visited := map[MessageRef]bool{{ChainID: chainID, BlockNumber: blockNumber, LogIndex: logIndex}: true}
+	if r.depsSafeAtLeast(msg.Deps, local, visited) {
+		return crossLevel(local), nil
+	}
+	return local, nil
+}
+
+// depsSafeAtLeast reports whether every message in deps (and everything they
+// transitively depend on) is at least as safe, in its own chain, as level.
+// visited guards against cycles in the dependency graph: a ref already being
+// walked is treated as satisfied rather than looped on forever.
+func (r *SafetyResolver) depsSafeAtLeast(deps []MessageRef, level types.SafetyLevel, visited map[MessageRef]bool) bool {
+	for _, dep := range deps {
+		if visited[dep] {
+			continue
+		}
+		visited[dep] = true
+
+		logDB, ok := r.logDBs[dep.ChainID]
+		if !ok {
+			return false
+		}
+		msg, ok, err := logDB.MessageAt(dep.BlockNumber, dep.LogIndex)
+		if err != nil || !ok {
+			return false
+		}
+		depLocal, err := r.heads.LocalSafetyLevel(dep.ChainID, dep.BlockNumber)
+		if err != nil {
+			return false
+		}
+		if rank(depLocal) < rank(level) {
+			return false
+		}
+		if len(msg.Deps) > 0 && !r.depsSafeAtLeast(msg.Deps, level, visited) {
+			return false
+		}
+	}
+	return true
+}
+
+// crossLevel maps a local safety level to its cross-chain counterpart.
+func crossLevel(local types.SafetyLevel) types.SafetyLevel {
+	switch local {
+	case types.Unsafe:
+		return types.CrossUnsafe
+	case types.Safe:
+		return types.CrossSafe
+	case types.Finalized:
+		return types.CrossFinalized
+	default:
+		return local
+	}
+}
+
+// rank gives safety levels a total order (least to most safe) so
+// depsSafeAtLeast and CheckBlock can compare across the Unsafe/Safe/Finalized
+// and their Cross* counterparts without relying on types.SafetyLevel's
+// underlying representation.
+func rank(level types.SafetyLevel) int {
+	switch level {
+	case types.Unsafe:
+		return 0
+	case types.CrossUnsafe:
+		return 1
+	case types.Safe:
+		return 2
+	case types.CrossSafe:
+		return 3
+	case types.Finalized:
+		return 4
+	case types.CrossFinalized:
+		return 5
+	default:
+		return -1
+	}
+}
+
+// CheckBlock returns the minimum safety level across every message logged in
+// the block identified by (blockHash, blockNumber) on chainID. A block with
+// no messages is as safe as its local level allows.
+func (r *SafetyResolver) CheckBlock(chainID types.ChainID, blockHash common.Hash, blockNumber uint64) (types.SafetyLevel, error) {
+	var zero types.SafetyLevel
+	logDB, ok := r.logDBs[chainID]
+	if !ok {
+		return zero, fmt.Errorf("%w: %v", ErrUnknownChain, chainID)
+	}
+	msgs, err := logDB.MessagesInBlock(blockHash, blockNumber)
+	if err != nil {
+		return zero, fmt.Errorf("failed to look up messages in block %v (%v) on chain %v: %w", blockNumber, blockHash, chainID, err)
+	}
+	local, err := r.heads.LocalSafetyLevel(chainID, blockNumber)
+	if err != nil {
+		return zero, fmt.Errorf("failed to get local safety level for chain %v block %v: %w", chainID, blockNumber, err)
+	}
+	if len(msgs) == 0 {
+		return crossLevel(local), nil
+	}
+	min := crossLevel(local)
+	for i, msg := range msgs {
+		visited := map[MessageRef]bool{}
+		lvl := local
+		if len(msg.Deps) == 0 || r.depsSafeAtLeast(msg.Deps, local, visited) {
+			lvl = crossLevel(local)
+		}
+		if i == 0 || rank(lvl) < rank(min) {
+			min = lvl
+		}
+	}
+	return min, nil
+}
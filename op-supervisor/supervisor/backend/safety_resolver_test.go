@@ -0,0 +1,204 @@
+package backend
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLogDB is an in-memory LogDB used to build synthetic multi-chain DAGs
+// for SafetyResolver tests, keyed by (blockNumber, logIndex).
+type fakeLogDB struct {
+	messages map[[2]uint64]Message
+	byBlock  map[common.Hash][]Message
+}
+
+func newFakeLogDB() *fakeLogDB {
+	return &fakeLogDB{
+		messages: make(map[[2]uint64]Message),
+		byBlock:  make(map[common.Hash][]Message),
+	}
+}
+
+func (f *fakeLogDB) add(blockNumber uint64, logIndex uint32, blockHash common.Hash, payloadHash common.Hash, deps ...MessageRef) {
+	msg := Message{PayloadHash: payloadHash, BlockHash: blockHash, Deps: deps}
+	f.messages[[2]uint64{blockNumber, uint64(logIndex)}] = msg
+	f.byBlock[blockHash] = append(f.byBlock[blockHash], msg)
+}
+
+func (f *fakeLogDB) MessageAt(blockNumber uint64, logIndex uint32) (Message, bool, error) {
+	msg, ok := f.messages[[2]uint64{blockNumber, uint64(logIndex)}]
+	return msg, ok, nil
+}
+
+func (f *fakeLogDB) MessagesInBlock(blockHash common.Hash, blockNumber uint64) ([]Message, error) {
+	return f.byBlock[blockHash], nil
+}
+
+// fakeHeadTracker reports a fixed local level per chain for every test.
+type fakeHeadTracker struct {
+	level map[types.ChainID]types.SafetyLevel
+}
+
+func (f *fakeHeadTracker) LocalSafetyLevel(chainID types.ChainID, blockNumber uint64) (types.SafetyLevel, error) {
+	lvl, ok := f.level[chainID]
+	if !ok {
+		return types.Unsafe, fmt.Errorf("no head state for chain %v", chainID)
+	}
+	return lvl, nil
+}
+
+func chainID(n int64) types.ChainID {
+	return types.ChainIDFromBig(big.NewInt(n))
+}
+
+func TestSafetyResolver_CheckMessage_NoDeps(t *testing.T) {
+	chainA := chainID(1)
+	dbA := newFakeLogDB()
+	payload := common.HexToHash("0xaaaa")
+	dbA.add(10, 0, common.HexToHash("0xb1"), payload)
+
+	heads := &fakeHeadTracker{level: map[types.ChainID]types.SafetyLevel{chainA: types.Safe}}
+	resolver := NewSafetyResolver(heads)
+	resolver.AddChain(chainA, dbA)
+
+	lvl, err := resolver.CheckMessage(chainA, 10, 0, payload)
+	require.NoError(t, err)
+	require.Equal(t, types.CrossSafe, lvl)
+}
+
+func TestSafetyResolver_CheckMessage_PayloadMismatch(t *testing.T) {
+	chainA := chainID(1)
+	dbA := newFakeLogDB()
+	dbA.add(10, 0, common.HexToHash("0xb1"), common.HexToHash("0xaaaa"))
+
+	heads := &fakeHeadTracker{level: map[types.ChainID]types.SafetyLevel{chainA: types.Safe}}
+	resolver := NewSafetyResolver(heads)
+	resolver.AddChain(chainA, dbA)
+
+	_, err := resolver.CheckMessage(chainA, 10, 0, common.HexToHash("0xbeef"))
+	require.ErrorIs(t, err, ErrPayloadMismatch)
+}
+
+func TestSafetyResolver_CheckMessage_SatisfiedDependency(t *testing.T) {
+	chainA, chainB := chainID(1), chainID(2)
+	dbA, dbB := newFakeLogDB(), newFakeLogDB()
+
+	initPayload := common.HexToHash("0x1111")
+	dbB.add(5, 0, common.HexToHash("0xb0"), initPayload)
+	execPayload := common.HexToHash("0x2222")
+	dbA.add(10, 0, common.HexToHash("0xa0"), execPayload, MessageRef{ChainID: chainB, BlockNumber: 5, LogIndex: 0})
+
+	heads := &fakeHeadTracker{level: map[types.ChainID]types.SafetyLevel{
+		chainA: types.Safe,
+		chainB: types.Safe,
+	}}
+	resolver := NewSafetyResolver(heads)
+	resolver.AddChain(chainA, dbA)
+	resolver.AddChain(chainB, dbB)
+
+	lvl, err := resolver.CheckMessage(chainA, 10, 0, execPayload)
+	require.NoError(t, err)
+	require.Equal(t, types.CrossSafe, lvl)
+}
+
+func TestSafetyResolver_CheckMessage_UnsafeDependencyBlocksPromotion(t *testing.T) {
+	chainA, chainB := chainID(1), chainID(2)
+	dbA, dbB := newFakeLogDB(), newFakeLogDB()
+
+	initPayload := common.HexToHash("0x1111")
+	dbB.add(5, 0, common.HexToHash("0xb0"), initPayload)
+	execPayload := common.HexToHash("0x2222")
+	dbA.add(10, 0, common.HexToHash("0xa0"), execPayload, MessageRef{ChainID: chainB, BlockNumber: 5, LogIndex: 0})
+
+	// chainA's own head says it's Safe, but the dependency on chainB is only Unsafe.
+	heads := &fakeHeadTracker{level: map[types.ChainID]types.SafetyLevel{
+		chainA: types.Safe,
+		chainB: types.Unsafe,
+	}}
+	resolver := NewSafetyResolver(heads)
+	resolver.AddChain(chainA, dbA)
+	resolver.AddChain(chainB, dbB)
+
+	lvl, err := resolver.CheckMessage(chainA, 10, 0, execPayload)
+	require.NoError(t, err)
+	require.Equal(t, types.Safe, lvl, "must not promote to CrossSafe while a dependency is less safe")
+}
+
+func TestSafetyResolver_CheckMessage_MissingDependencyBlocksPromotion(t *testing.T) {
+	chainA, chainB := chainID(1), chainID(2)
+	dbA, dbB := newFakeLogDB(), newFakeLogDB()
+
+	execPayload := common.HexToHash("0x2222")
+	// Dependency on chainB block 5 log 0 is never added to dbB.
+	dbA.add(10, 0, common.HexToHash("0xa0"), execPayload, MessageRef{ChainID: chainB, BlockNumber: 5, LogIndex: 0})
+
+	heads := &fakeHeadTracker{level: map[types.ChainID]types.SafetyLevel{
+		chainA: types.Safe,
+		chainB: types.Safe,
+	}}
+	resolver := NewSafetyResolver(heads)
+	resolver.AddChain(chainA, dbA)
+	resolver.AddChain(chainB, dbB)
+
+	lvl, err := resolver.CheckMessage(chainA, 10, 0, execPayload)
+	require.NoError(t, err)
+	require.Equal(t, types.Safe, lvl)
+}
+
+func TestSafetyResolver_CheckMessage_CyclicDependencyTerminates(t *testing.T) {
+	chainA, chainB := chainID(1), chainID(2)
+	dbA, dbB := newFakeLogDB(), newFakeLogDB()
+
+	payloadA := common.HexToHash("0xa000")
+	payloadB := common.HexToHash("0xb000")
+	// A depends on B, B depends back on A: a two-node cycle.
+	dbA.add(10, 0, common.HexToHash("0xa0"), payloadA, MessageRef{ChainID: chainB, BlockNumber: 20, LogIndex: 0})
+	dbB.add(20, 0, common.HexToHash("0xb0"), payloadB, MessageRef{ChainID: chainA, BlockNumber: 10, LogIndex: 0})
+
+	heads := &fakeHeadTracker{level: map[types.ChainID]types.SafetyLevel{
+		chainA: types.Safe,
+		chainB: types.Safe,
+	}}
+	resolver := NewSafetyResolver(heads)
+	resolver.AddChain(chainA, dbA)
+	resolver.AddChain(chainB, dbB)
+
+	lvl, err := resolver.CheckMessage(chainA, 10, 0, payloadA)
+	require.NoError(t, err)
+	require.Equal(t, types.CrossSafe, lvl, "a cycle where every node is Safe should still promote")
+}
+
+func TestSafetyResolver_CheckBlock_MinAcrossMessages(t *testing.T) {
+	chainA, chainB := chainID(1), chainID(2)
+	dbA, dbB := newFakeLogDB(), newFakeLogDB()
+
+	blockHash := common.HexToHash("0xa0")
+	// One message with a satisfied dependency, one with a missing dependency:
+	// CheckBlock must report the lower of the two.
+	dbB.add(5, 0, common.HexToHash("0xb0"), common.HexToHash("0x1111"))
+	dbA.add(10, 0, blockHash, common.HexToHash("0x2222"), MessageRef{ChainID: chainB, BlockNumber: 5, LogIndex: 0})
+	dbA.add(10, 1, blockHash, common.HexToHash("0x3333"), MessageRef{ChainID: chainB, BlockNumber: 99, LogIndex: 0})
+
+	heads := &fakeHeadTracker{level: map[types.ChainID]types.SafetyLevel{
+		chainA: types.Safe,
+		chainB: types.Safe,
+	}}
+	resolver := NewSafetyResolver(heads)
+	resolver.AddChain(chainA, dbA)
+	resolver.AddChain(chainB, dbB)
+
+	lvl, err := resolver.CheckBlock(chainA, blockHash, 10)
+	require.NoError(t, err)
+	require.Equal(t, types.Safe, lvl)
+}
+
+func TestSafetyResolver_UnknownChain(t *testing.T) {
+	resolver := NewSafetyResolver(&fakeHeadTracker{level: map[types.ChainID]types.SafetyLevel{}})
+	_, err := resolver.CheckMessage(chainID(1), 10, 0, common.Hash{})
+	require.ErrorIs(t, err, ErrUnknownChain)
+}